@@ -4,19 +4,24 @@
 		> determine which, and if valid
 	- convert to the other numeral type R -> A or A -> R
 	- take flag for subtractive or additive output
-	- valid range of arabic numbers 1 - 4000 (MMMM)
+	- valid range of arabic numbers 1 - 3999 (MMMCMXCIX); additive output
+	  extends to 4000 (MMMM)
 */
 
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/AndrewsPrivateStash/roman-converter/pkg/roman"
 )
 
 var usage = `Roman numeral converter (Arabic to Roman & Roman to Arabic)
@@ -36,6 +41,11 @@ Options:
 	o	<bool>	  defalt=false		write output to local file
 	p	<string>  default="out.txt"	the filename to produce
 	app	<bool>	  default=false		write in append mode
+	lax	<bool>	  default=false		accept non-canonical roman numerals (e.g. "IIII", "MDCCCCLXV")
+	big	<bool>	  default=false		support the extended range up to 3,999,999 using vinculum notation
+	ascii	<bool>	  default=false		with -big, use an ASCII "(V)" fallback instead of the combining overline
+	stdin	<bool>	  default=false		read one numeral per line from stdin and write results to stdout (or -p if -o is set)
+	strict	<bool>	  default=false		with -stdin, abort on the first unconvertible line instead of emitting a "# error" line
 `
 
 var (
@@ -48,51 +58,21 @@ var (
 	writeFileF  = flag.Bool("o", false, "produce an output file with output")
 	outpathF    = flag.String("p", "out.txt", "relative path of the output file")
 	appendFileF = flag.Bool("app", false, "append file write versus truncate")
+	laxF        = flag.Bool("lax", false, "accept non-canonical roman numerals")
+	bigF        = flag.Bool("big", false, "support the extended range up to 3,999,999 via vinculum notation")
+	asciiF      = flag.Bool("ascii", false, "with -big, use an ASCII parenthesis fallback instead of the combining overline")
+	stdinF      = flag.Bool("stdin", false, "read one numeral per line from stdin and stream results out")
+	strictF     = flag.Bool("strict", false, "with -stdin, abort on the first unconvertible line")
 )
 
-type NumType uint8
-
-const (
-	Arabic NumType = iota
-	Roman
-	UnDef
-)
-
-var aTorMap = map[uint16]string{
-	1000: "M",
-	900:  "CM",
-	500:  "D",
-	400:  "CD",
-	100:  "C",
-	90:   "XC",
-	50:   "L",
-	40:   "XL",
-	10:   "X",
-	9:    "IX",
-	5:    "V",
-	4:    "IV",
-	1:    "I",
-}
-
-var rToaMap = map[string]uint16{
-	"M":  1000,
-	"CM": 900,
-	"D":  500,
-	"CD": 400,
-	"C":  100,
-	"XC": 90,
-	"L":  50,
-	"XL": 40,
-	"X":  10,
-	"IX": 9,
-	"V":  5,
-	"IV": 4,
-	"I":  1,
-}
-
 func main() {
 	flag.Parse()
 
+	if *stdinF {
+		runStdin()
+		return
+	}
+
 	val := strings.ToUpper(flag.Arg(0))
 	if val == "" && !*rangeF {
 		fmt.Print(usage)
@@ -115,163 +95,232 @@ func main() {
 		}
 	}
 
+	// extended vinculum range case
+	if *bigF {
+		runBig(val)
+		return
+	}
+
 	// single value case
-	theNumType := whichNumeralType(val)
+	outStr, err := convertOne(val)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *writeFileF {
+		writeToFile([]string{outStr})
+	} else {
+		fmt.Println(outStr)
+	}
+}
 
-	switch theNumType {
-	case Arabic:
-		convVal := makeInt64(val)
-		if *writeFileF {
-			writeToFile([]string{formatValue(convVal, arabicToRoman(convVal), Roman)})
-		} else {
-			fmt.Println(formatValue(convVal, arabicToRoman(convVal), Roman))
+// convertOne auto-detects whether val is an arabic or roman numeral,
+// converts it to the other form, and returns the formatted result. It is
+// shared by the single-value CLI path and the -stdin streaming path, and
+// never calls log.Fatalf so a bad line doesn't abort the whole run.
+func convertOne(val string) (string, error) {
+	val = strings.ToUpper(strings.TrimSpace(val))
+
+	if *bigF {
+		return convertOneBig(val)
+	}
+
+	switch roman.WhichNumeralType(val) {
+	case roman.TypeArabic:
+		n, err := strconv.ParseUint(val, 10, 16)
+		if err != nil {
+			return "", err
 		}
+		convVal := uint16(n)
 
-	case Roman:
-		if *writeFileF {
-			writeToFile([]string{formatValue(romanToArabic(val), val, Arabic)})
+		var romVal string
+		if *addF {
+			romVal, err = roman.ConvertToRomanAdditive(convVal)
 		} else {
-			fmt.Println(formatValue(romanToArabic(val), val, Arabic))
+			romVal, err = roman.ConvertToRoman(convVal)
 		}
+		if err != nil {
+			return "", err
+		}
+
+		return formatValue(convVal, romVal, roman.TypeRoman), nil
+
+	case roman.TypeRoman:
+		arVal, err := toArabic(val)
+		if err != nil {
+			return "", err
+		}
+
+		return formatValue(arVal, val, roman.TypeArabic), nil
 
 	default:
-		log.Fatalf("%s is not defined and is neither roman or arabic\n", val)
+		return "", fmt.Errorf("%q is not defined and is neither roman or arabic", val)
 	}
 }
 
-func whichNumeralType(str string) NumType {
-	// is the value Roman, Arabic, or neither
-	var arabicPattern = regexp.MustCompile(`^[1-9]\d*$`)
-	var romanPattern = regexp.MustCompile(`^[IVXLCDM]+$`)
-
-	if arabicPattern.MatchString(str) {
-		return Arabic
+// runStdin reads one numeral per line from stdin, converts each via
+// convertOne, and streams the results to stdout (or -p if -o is set).
+// A line that fails to convert emits a "# error" comment line rather
+// than aborting the run, unless -strict is set.
+func runStdin() {
+	scanner := bufio.NewScanner(os.Stdin)
+
+	var out io.Writer = os.Stdout
+	if *writeFileF {
+		f, err := openStreamFile()
+		if err != nil {
+			log.Fatalf("failed to open file %s for writing\n%v", *outpathF, err)
+		}
+		defer f.Close()
+		out = f
 	}
 
-	if romanPattern.MatchString(str) {
-		return Roman
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		outStr, err := convertOne(line)
+		if err != nil {
+			if *strictF {
+				log.Fatalf("%s: %v", line, err)
+			}
+			fmt.Fprintf(w, "# error: %s: %v\n", line, err)
+			continue
+		}
+
+		fmt.Fprintln(w, outStr)
 	}
 
-	return UnDef
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("error reading stdin: %v", err)
+	}
 }
 
-func isValArabic(num int64) error {
-	// number must be less than 4000
-	if num > 4000 {
-		return fmt.Errorf("%d is greater than 4000", num)
+// openStreamFile opens -p for the -stdin write path, honoring -app.
+func openStreamFile() (*os.File, error) {
+	writeType := os.O_WRONLY | os.O_CREATE
+	if *appendFileF {
+		writeType |= os.O_APPEND
+	} else {
+		writeType |= os.O_TRUNC
 	}
+	return os.OpenFile(*outpathF, writeType, 0664)
+}
 
-	if num < 1 {
-		return fmt.Errorf("%d is less than 1", num)
+// toRoman converts val using the additive or subtractive map depending
+// on the -a flag.
+func toRoman(val uint16) (string, error) {
+	if *addF {
+		return roman.ConvertToRomanAdditive(val)
 	}
+	return roman.ConvertToRoman(val)
+}
 
-	return nil
+// toArabic converts str using lax (permissive) or strict (canonical
+// grammar) rules depending on the -lax flag.
+func toArabic(str string) (uint16, error) {
+	if *laxF {
+		return roman.ConvertToArabicLax(str)
+	}
+	return roman.ConvertToArabic(str)
 }
 
-func makeInt64(str string) int64 {
-	convVal, err := strconv.ParseInt(str, 10, 64)
+// runBig handles the single-value case for the -big vinculum-notation
+// mode, where values run up to 3,999,999 and no longer fit in a uint16.
+func runBig(val string) {
+	outStr, err := convertOneBig(val)
 	if err != nil {
-		log.Fatalf("%s cannot be converted to an int!\n", str)
-	}
-	if err := isValArabic(convVal); err != nil {
 		log.Fatalf("%v", err)
 	}
-	return convVal
-}
 
-func romanToArabic(str string) int64 {
-	// take a valid roman numeral and return an arabic numeral
-	//	run through string left to right, check two chars if avaialble against map
-	//	and grab value to add to running total until chars are exhausted
-
-	var val int64
-
-	for i := 0; i < len(str); {
-		c := str[i]
+	if *writeFileF {
+		writeToFile([]string{outStr})
+	} else {
+		fmt.Println(outStr)
+	}
+}
 
-		// grab next char if possible
-		var xc []byte
-		if i+1 < len(str) {
-			xc = append([]byte{c}, str[i+1])
+// convertOneBig is the -big counterpart to convertOne: it auto-detects
+// whether val is an arabic or vinculum-notation roman numeral, converts
+// it to the other form, and returns the formatted result. Shared by the
+// -big single-value path and -stdin -big streaming, so it never calls
+// log.Fatalf.
+func convertOneBig(val string) (string, error) {
+	switch roman.WhichNumeralTypeExtended(val) {
+	case roman.TypeArabic:
+		n, err := strconv.ParseUint(val, 10, 32)
+		if err != nil {
+			return "", err
 		}
-
-		// check two char sequence first
-		if v, fnd := rToaMap[string(xc)]; fnd {
-			val += int64(v)
-			i += 2
-			continue
+		convVal := uint32(n)
+		if err := roman.IsValidArabicExtended(convVal); err != nil {
+			return "", err
 		}
 
-		if v, fnd := rToaMap[string(c)]; fnd {
-			val += int64(v)
-			i++
-			continue
-		} else {
-			log.Fatalf("%s was not found in roman to arabic map\n invalid character", str)
+		romVal, err := toRomanExtended(convVal)
+		if err != nil {
+			return "", err
 		}
 
-	}
+		return formatBigValue(convVal, romVal, roman.TypeRoman), nil
 
-	return val
-}
-
-func arabicToRoman(val int64) string {
-	// take an arabic numeral and return a roman numeral
-	// loop over map to find greatst match for current value
-	//	apend the value and decrease current by key
-	// coninue until current is zero
+	case roman.TypeRoman:
+		arVal, err := toArabicExtended(val)
+		if err != nil {
+			return "", err
+		}
 
-	var (
-		outStr  string
-		current int64 = val
-	)
+		return formatBigValue(arVal, val, roman.TypeArabic), nil
 
-	useMap := aTorMap
-	if *addF {
-		useMap = makeAddMap(aTorMap)
+	default:
+		return "", fmt.Errorf("%q is not defined and is neither roman or arabic", val)
 	}
+}
 
-	for current > 0 {
-		a, r := findLargest(current, useMap)
-		outStr += r
-		current -= int64(a)
+// toArabicExtended converts a vinculum-notation string using lax
+// (permissive) or strict (canonical grammar) rules depending on the
+// -lax flag.
+func toArabicExtended(str string) (uint32, error) {
+	if *laxF {
+		return roman.ConvertToArabicExtendedLax(str)
 	}
-
-	return outStr
+	return roman.ConvertToArabicExtended(str)
 }
 
-func findLargest(n int64, m map[uint16]string) (a uint16, r string) {
-	// find largest key in map <= n
-	var (
-		lAr uint16
-		lRm string
-	)
-	for k, v := range m {
-		if uint16(n) >= k && k > lAr { //assume n is in uint16 space
-			lAr = k
-			lRm = v
-		}
+// toRomanExtended converts val to vinculum notation, using the ASCII
+// fallback when -ascii is set.
+func toRomanExtended(val uint32) (string, error) {
+	if *asciiF {
+		return roman.ConvertToRomanExtendedASCII(val)
 	}
-
-	return lAr, lRm
+	return roman.ConvertToRomanExtended(val)
 }
 
-func makeAddMap(inmap map[uint16]string) map[uint16]string {
-	// take exisitng A -> R map and
-	// return new map sans subtractive elements
-	outmap := map[uint16]string{}
-	for k, v := range inmap {
-		if len(v) == 1 { // assume all subtractive keys are two bytes
-			outmap[k] = v
+func formatBigValue(arVal uint32, romVal string, outType roman.NumType) string {
+	switch outType {
+	case roman.TypeRoman:
+		if *simpleOutF {
+			return romVal
+		}
+		return fmt.Sprintf("%d = %s", arVal, romVal)
+	case roman.TypeArabic:
+		if *simpleOutF {
+			return fmt.Sprintf("%d", arVal)
 		}
+		return fmt.Sprintf("%s = %d", romVal, arVal)
+	default:
+		return "NA"
 	}
-	return outmap
 }
 
-func formatValue(arVal int64, romVal string, outType NumType) string {
+func formatValue(arVal uint16, romVal string, outType roman.NumType) string {
 	var outStr string
 	switch outType {
-	case Roman:
+	case roman.TypeRoman:
 		if *simpleOutF {
 			outStr = romVal
 		} else {
@@ -280,7 +329,7 @@ func formatValue(arVal int64, romVal string, outType NumType) string {
 				outStr += "\t (add)"
 			}
 		}
-	case Arabic:
+	case roman.TypeArabic:
 		if *simpleOutF {
 			outStr = fmt.Sprintf("%d", arVal)
 		} else {
@@ -296,8 +345,18 @@ func formatValue(arVal int64, romVal string, outType NumType) string {
 func genRange() []string {
 	outVals := []string{}
 	for i := *startF; i <= *endF; i++ {
-		appendStr := formatValue(int64(i), arabicToRoman(int64(i)), Roman)
-		outVals = append(outVals, appendStr)
+		if i < 0 || i > math.MaxUint16 {
+			outVals = append(outVals, fmt.Sprintf("# error: %d: %v", i, roman.ErrOutOfRange))
+			continue
+		}
+
+		v := uint16(i)
+		romVal, err := toRoman(v)
+		if err != nil {
+			outVals = append(outVals, fmt.Sprintf("# error: %d: %v", i, err))
+			continue
+		}
+		outVals = append(outVals, formatValue(v, romVal, roman.TypeRoman))
 	}
 
 	return outVals
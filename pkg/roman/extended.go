@@ -0,0 +1,210 @@
+package roman
+
+import (
+	"regexp"
+	"strings"
+)
+
+// overline is the Unicode combining overline (U+0305) used to mark a
+// roman numeral letter as multiplied by 1000 (vinculum notation), e.g.
+// "V̅" = 5000.
+const overline = '\u0305'
+
+const (
+	minExtendedVal uint32 = 1
+	maxExtendedVal uint32 = 3_999_999
+)
+
+// extendedRomanPattern matches the character set used by vinculum
+// notation: plain roman letters, ASCII fallback parentheses, and the
+// combining overline mark itself. WhichNumeralType doesn't recognize
+// these strings since its roman pattern is plain [IVXLCDM].
+var extendedRomanPattern = regexp.MustCompile(`^[IVXLCDM()` + string(overline) + `]+$`)
+
+// WhichNumeralTypeExtended is the -big counterpart to WhichNumeralType:
+// it additionally recognizes vinculum-notation roman numerals, in
+// either combining-overline or parenthesized ASCII form.
+func WhichNumeralTypeExtended(str string) NumType {
+	if arabicPattern.MatchString(str) {
+		return TypeArabic
+	}
+
+	if extendedRomanPattern.MatchString(str) {
+		return TypeRoman
+	}
+
+	return TypeUndef
+}
+
+// IsValidArabicExtended reports whether num is within the extended
+// vinculum-notation range.
+func IsValidArabicExtended(num uint32) error {
+	if num > maxExtendedVal {
+		return ErrOutOfRange
+	}
+
+	if num < minExtendedVal {
+		return ErrOutOfRange
+	}
+
+	return nil
+}
+
+// ConvertToRomanExtended converts an arabic value up to 3,999,999 to
+// roman numeral form using vinculum notation: the value is split into a
+// thousands part and a remainder, each rendered with the ordinary
+// subtractive algorithm, and the thousands part is overlined with the
+// combining U+0305 mark to show it is multiplied by 1000, e.g.
+// 5000 -> "V̅", 1965000 -> "M̅C̅M̅L̅X̅V̅".
+// Use ConvertToRomanExtendedASCII for output that can't render combining
+// marks.
+func ConvertToRomanExtended(val uint32) (string, error) {
+	thousands, remainder, err := splitThousands(val)
+	if err != nil {
+		return "", err
+	}
+
+	return overlineUnicode(arabicToRoman(thousands, aTorMap)) + arabicToRoman(remainder, aTorMap), nil
+}
+
+// ConvertToRomanExtendedASCII is like ConvertToRomanExtended but marks
+// the overlined thousands part by wrapping each letter in parentheses,
+// e.g. 5000 -> "(V)", for terminals and files that can't render the
+// combining overline.
+func ConvertToRomanExtendedASCII(val uint32) (string, error) {
+	thousands, remainder, err := splitThousands(val)
+	if err != nil {
+		return "", err
+	}
+
+	return overlineASCII(arabicToRoman(thousands, aTorMap)) + arabicToRoman(remainder, aTorMap), nil
+}
+
+// ConvertToArabicExtended converts a vinculum-notation roman numeral
+// string, in either combining-overline or parenthesized ASCII form, to
+// its arabic value. Both the overlined thousands part and the remainder
+// must follow canonical subtractive grammar (see IsCanonicalRoman); use
+// ConvertToArabicExtendedLax to accept non-canonical forms.
+func ConvertToArabicExtended(str string) (uint32, error) {
+	return convertArabicExtended(str, decodePart)
+}
+
+// ConvertToArabicExtendedLax is like ConvertToArabicExtended but does
+// not enforce canonical grammar on either part, preserving the
+// historically permissive behavior for additive forms.
+func ConvertToArabicExtendedLax(str string) (uint32, error) {
+	return convertArabicExtended(str, decodePartLax)
+}
+
+func convertArabicExtended(str string, decode func(string) (uint16, error)) (uint32, error) {
+	thousandsPart, remainderPart, err := splitExtendedString(str)
+	if err != nil {
+		return 0, err
+	}
+
+	thousandsVal, err := decode(thousandsPart)
+	if err != nil {
+		return 0, err
+	}
+
+	remainderVal, err := decode(remainderPart)
+	if err != nil {
+		return 0, err
+	}
+
+	total := uint32(thousandsVal)*1000 + uint32(remainderVal)
+	if err := IsValidArabicExtended(total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// splitThousands splits val into a thousands part and a remainder, each
+// within the ordinary [0, 3999] range the base algorithm handles.
+func splitThousands(val uint32) (thousands uint16, remainder uint16, err error) {
+	if err := IsValidArabicExtended(val); err != nil {
+		return 0, 0, err
+	}
+
+	return uint16(val / 1000), uint16(val % 1000), nil
+}
+
+// overlineUnicode inserts a combining overline after every rune in s.
+func overlineUnicode(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		sb.WriteRune(r)
+		sb.WriteRune(overline)
+	}
+	return sb.String()
+}
+
+// overlineASCII wraps every rune in s in its own pair of parentheses.
+func overlineASCII(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		sb.WriteByte('(')
+		sb.WriteRune(r)
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
+
+// splitExtendedString separates an extended roman numeral string into
+// its overlined thousands letters (stripped of the overline marking, be
+// it a combining mark or parentheses) and its plain remainder letters.
+func splitExtendedString(str string) (thousands string, remainder string, err error) {
+	runes := []rune(str)
+
+	var thousandsB, remainderB strings.Builder
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		if r == '(' {
+			j := i + 1
+			for j < len(runes) && runes[j] != ')' {
+				j++
+			}
+			if j >= len(runes) {
+				return "", "", ErrInvalidNumeral
+			}
+			thousandsB.WriteString(string(runes[i+1 : j]))
+			i = j + 1
+			continue
+		}
+
+		if i+1 < len(runes) && runes[i+1] == overline {
+			thousandsB.WriteRune(r)
+			i += 2
+			continue
+		}
+
+		remainderB.WriteRune(r)
+		i++
+	}
+
+	return thousandsB.String(), remainderB.String(), nil
+}
+
+// decodePart converts a plain (non-overlined) roman numeral substring
+// under canonical grammar, returning 0 for an empty substring.
+func decodePart(s string) (uint16, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if !IsCanonicalRoman(s) {
+		return 0, ErrInvalidNumeral
+	}
+
+	return romanToArabic(s)
+}
+
+// decodePartLax is like decodePart but accepts non-canonical forms.
+func decodePartLax(s string) (uint16, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	return romanToArabic(s)
+}
@@ -0,0 +1,75 @@
+package roman
+
+import "testing"
+
+func TestConvertToRomanExtended(t *testing.T) {
+	cases := []struct {
+		val  uint32
+		want string
+	}{
+		{5000, "V̅"},
+		{10000, "X̅"},
+		{1965000, "M̅C̅M̅L̅X̅V̅"},
+		{1965, "I̅CMLXV"},
+		{3999999, "M̅M̅M̅C̅M̅X̅C̅I̅X̅CMXCIX"},
+	}
+
+	for _, c := range cases {
+		got, err := ConvertToRomanExtended(c.val)
+		if err != nil {
+			t.Fatalf("ConvertToRomanExtended(%d): unexpected error %v", c.val, err)
+		}
+		if got != c.want {
+			t.Errorf("ConvertToRomanExtended(%d) = %q, want %q", c.val, got, c.want)
+		}
+	}
+}
+
+func TestConvertToRomanExtendedASCII(t *testing.T) {
+	got, err := ConvertToRomanExtendedASCII(5000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "(V)"; got != want {
+		t.Errorf("ConvertToRomanExtendedASCII(5000) = %q, want %q", got, want)
+	}
+}
+
+func TestConvertToArabicExtendedRoundTrip(t *testing.T) {
+	for _, val := range []uint32{1, 999, 1000, 4000, 5000, 10000, 1965000, 3999999} {
+		roman, err := ConvertToRomanExtended(val)
+		if err != nil {
+			t.Fatalf("ConvertToRomanExtended(%d): unexpected error %v", val, err)
+		}
+
+		back, err := ConvertToArabicExtended(roman)
+		if err != nil {
+			t.Fatalf("ConvertToArabicExtended(%q): unexpected error %v", roman, err)
+		}
+		if back != val {
+			t.Errorf("round trip for %d produced %q -> %d", val, roman, back)
+		}
+
+		ascii, err := ConvertToRomanExtendedASCII(val)
+		if err != nil {
+			t.Fatalf("ConvertToRomanExtendedASCII(%d): unexpected error %v", val, err)
+		}
+
+		back, err = ConvertToArabicExtended(ascii)
+		if err != nil {
+			t.Fatalf("ConvertToArabicExtended(%q): unexpected error %v", ascii, err)
+		}
+		if back != val {
+			t.Errorf("ASCII round trip for %d produced %q -> %d", val, ascii, back)
+		}
+	}
+}
+
+func TestConvertToRomanExtendedOutOfRange(t *testing.T) {
+	if _, err := ConvertToRomanExtended(0); err != ErrOutOfRange {
+		t.Errorf("ConvertToRomanExtended(0) error = %v, want %v", err, ErrOutOfRange)
+	}
+	if _, err := ConvertToRomanExtended(4_000_000); err != ErrOutOfRange {
+		t.Errorf("ConvertToRomanExtended(4000000) error = %v, want %v", err, ErrOutOfRange)
+	}
+}
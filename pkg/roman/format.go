@@ -0,0 +1,129 @@
+package roman
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Roman is an arabic value that prints, marshals, and scans as a roman
+// numeral, so it can be used directly in structs, configs, and JSON
+// payloads instead of only through the CLI.
+type Roman uint16
+
+// Format implements fmt.Formatter.
+//
+//	%s, %v	subtractive form, e.g. "MCMLXV"
+//	%+v	long form, e.g. "1965 = MCMLXV"
+//	%#v	Go-syntax literal, e.g. "roman.Roman(1965)"
+//	%a	additive form, e.g. "MDCCCCLXV" ('+' flag gives the long form)
+func (r Roman) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		io.WriteString(f, r.numeral(f.Flag('+')))
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			fmt.Fprintf(f, "roman.Roman(%d)", uint16(r))
+		case f.Flag('+'):
+			fmt.Fprintf(f, "%d = %s", uint16(r), r.numeral(false))
+		default:
+			io.WriteString(f, r.numeral(false))
+		}
+	case 'a':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%d = %s", uint16(r), r.numeral(true))
+		} else {
+			io.WriteString(f, r.numeral(true))
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(roman.Roman=%d)", verb, uint16(r))
+	}
+}
+
+// numeral renders r as subtractive or additive roman numeral text,
+// falling back to an error marker for out-of-range values.
+func (r Roman) numeral(additive bool) string {
+	var (
+		s   string
+		err error
+	)
+
+	if additive {
+		s, err = ConvertToRomanAdditive(uint16(r))
+	} else {
+		s, err = ConvertToRoman(uint16(r))
+	}
+	if err != nil {
+		return fmt.Sprintf("%%!roman(%d)", uint16(r))
+	}
+
+	return s
+}
+
+// String implements fmt.Stringer with the subtractive form.
+func (r Roman) String() string {
+	return r.numeral(false)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Roman) MarshalText() ([]byte, error) {
+	s, err := ConvertToRoman(uint16(r))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Roman) UnmarshalText(text []byte) error {
+	v, err := ConvertToArabic(string(text))
+	if err != nil {
+		return err
+	}
+	*r = Roman(v)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, e.g. json.Marshal(Roman(1965))
+// produces "MCMLXV".
+func (r Roman) MarshalJSON() ([]byte, error) {
+	s, err := ConvertToRoman(uint16(r))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Roman) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	v, err := ConvertToArabic(s)
+	if err != nil {
+		return err
+	}
+	*r = Roman(v)
+	return nil
+}
+
+// Scan implements fmt.Scanner, so fmt.Sscan("MCMLXV", &r) populates r.
+func (r *Roman) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := state.Token(true, func(c rune) bool {
+		return strings.ContainsRune("IVXLCDM", c)
+	})
+	if err != nil {
+		return err
+	}
+
+	v, err := ConvertToArabic(string(tok))
+	if err != nil {
+		return err
+	}
+	*r = Roman(v)
+	return nil
+}
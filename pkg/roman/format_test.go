@@ -0,0 +1,57 @@
+package roman
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestRomanFormat(t *testing.T) {
+	r := Roman(1965)
+
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{"%s", "MCMLXV"},
+		{"%v", "MCMLXV"},
+		{"%+v", "1965 = MCMLXV"},
+		{"%#v", "roman.Roman(1965)"},
+		{"%a", "MDCCCCLXV"},
+		{"%+a", "1965 = MDCCCCLXV"},
+	}
+
+	for _, c := range cases {
+		if got := fmt.Sprintf(c.format, r); got != c.want {
+			t.Errorf("fmt.Sprintf(%q, r) = %q, want %q", c.format, got, c.want)
+		}
+	}
+}
+
+func TestRomanJSON(t *testing.T) {
+	b, err := json.Marshal(Roman(1965))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got, want := string(b), `"MCMLXV"`; got != want {
+		t.Errorf("Marshal(Roman(1965)) = %s, want %s", got, want)
+	}
+
+	var r Roman
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if r != 1965 {
+		t.Errorf("Unmarshal(%s) = %d, want 1965", b, r)
+	}
+}
+
+func TestRomanScan(t *testing.T) {
+	var r Roman
+	if _, err := fmt.Sscan("MCMLXV", &r); err != nil {
+		t.Fatalf("Sscan: %v", err)
+	}
+	if r != 1965 {
+		t.Errorf("Sscan(\"MCMLXV\", &r) = %d, want 1965", r)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+	roman numeral conversion library
+	- determine whether a string is an arabic or roman numeral
+	- convert arabic <-> roman
+	- subtractive (canonical) or additive output
+	- valid range of arabic numbers 1 - 3999 (MMMCMXCIX); additive output
+	  extends to 4000 (MMMM), the one value only representable non-canonically
+*/
+
+package roman
+
+import (
+	"errors"
+	"regexp"
+)
+
+// ErrOutOfRange is returned when an arabic value falls outside the
+// supported range for conversion.
+var ErrOutOfRange = errors.New("roman: arabic value out of range")
+
+// ErrInvalidNumeral is returned when a string is not a valid roman numeral.
+var ErrInvalidNumeral = errors.New("roman: invalid roman numeral")
+
+// NumType identifies whether a string is an arabic numeral, a roman
+// numeral, or neither.
+type NumType uint8
+
+const (
+	TypeArabic NumType = iota
+	TypeRoman
+	TypeUndef
+)
+
+const (
+	minVal uint16 = 1
+	maxVal uint16 = 3999
+
+	// maxValAdditive is one higher than maxVal: 4000 ("MMMM") renders
+	// identically under subtractive and additive rules, so it can't
+	// round-trip through the canonical grammar ConvertToArabic enforces,
+	// but it round-trips fine through the permissive additive/lax path.
+	maxValAdditive uint16 = 4000
+)
+
+var aTorMap = map[uint16]string{
+	1000: "M",
+	900:  "CM",
+	500:  "D",
+	400:  "CD",
+	100:  "C",
+	90:   "XC",
+	50:   "L",
+	40:   "XL",
+	10:   "X",
+	9:    "IX",
+	5:    "V",
+	4:    "IV",
+	1:    "I",
+}
+
+var rToaMap = map[string]uint16{
+	"M":  1000,
+	"CM": 900,
+	"D":  500,
+	"CD": 400,
+	"C":  100,
+	"XC": 90,
+	"L":  50,
+	"XL": 40,
+	"X":  10,
+	"IX": 9,
+	"V":  5,
+	"IV": 4,
+	"I":  1,
+}
+
+var (
+	arabicPattern    = regexp.MustCompile(`^[1-9]\d*$`)
+	romanPattern     = regexp.MustCompile(`^[IVXLCDM]+$`)
+	canonicalPattern = regexp.MustCompile(`^M{0,3}(CM|CD|D?C{0,3})(XC|XL|L?X{0,3})(IX|IV|V?I{0,3})$`)
+)
+
+// WhichNumeralType reports whether str is an arabic numeral, a roman
+// numeral, or neither.
+func WhichNumeralType(str string) NumType {
+	if arabicPattern.MatchString(str) {
+		return TypeArabic
+	}
+
+	if romanPattern.MatchString(str) {
+		return TypeRoman
+	}
+
+	return TypeUndef
+}
+
+// IsCanonicalRoman reports whether str follows canonical subtractive
+// roman numeral grammar: at most three consecutive I/X/C/M, at most one
+// D/L/V, and only the legal subtractive pairs (IV, IX, XL, XC, CD, CM).
+func IsCanonicalRoman(str string) bool {
+	return canonicalPattern.MatchString(str)
+}
+
+// IsValidArabic reports whether num is within the convertible range for
+// canonical (subtractive) roman numeral conversion. ConvertToRomanAdditive
+// accepts one value beyond this range; see maxValAdditive.
+func IsValidArabic(num uint16) error {
+	if num > maxVal {
+		return ErrOutOfRange
+	}
+
+	if num < minVal {
+		return ErrOutOfRange
+	}
+
+	return nil
+}
+
+// ConvertToRoman converts an arabic value to its canonical subtractive
+// roman numeral form, e.g. 1965 -> "MCMLXV".
+func ConvertToRoman(val uint16) (string, error) {
+	if err := IsValidArabic(val); err != nil {
+		return "", err
+	}
+
+	return arabicToRoman(val, aTorMap), nil
+}
+
+// ConvertToRomanAdditive converts an arabic value to additive roman
+// numeral form, e.g. 1965 -> "MDCCCCLXV". Unlike ConvertToRoman, it
+// also accepts 4000 ("MMMM"), since that value isn't canonical and so
+// falls outside ConvertToRoman's range but round-trips fine through
+// ConvertToArabicLax.
+func ConvertToRomanAdditive(val uint16) (string, error) {
+	if val < minVal || val > maxValAdditive {
+		return "", ErrOutOfRange
+	}
+
+	return arabicToRoman(val, makeAddMap(aTorMap)), nil
+}
+
+// ConvertToArabic converts a roman numeral string to its arabic value.
+// str must follow canonical subtractive grammar (see IsCanonicalRoman);
+// non-canonical strings such as "IIII" or "IC" return ErrInvalidNumeral.
+// Use ConvertToArabicLax to accept historically-attested additive forms.
+func ConvertToArabic(str string) (uint16, error) {
+	if WhichNumeralType(str) != TypeRoman || !IsCanonicalRoman(str) {
+		return 0, ErrInvalidNumeral
+	}
+
+	return romanToArabic(str)
+}
+
+// ConvertToArabicLax converts a roman numeral string to its arabic value
+// without enforcing canonical grammar, preserving the historically
+// permissive behavior so additive forms like "MDCCCCLXV" still convert.
+func ConvertToArabicLax(str string) (uint16, error) {
+	if WhichNumeralType(str) != TypeRoman {
+		return 0, ErrInvalidNumeral
+	}
+
+	return romanToArabic(str)
+}
+
+// romanToArabic takes a roman numeral string and returns its arabic value.
+//
+//	run through string left to right, check two chars if avaialble against map
+//	and grab value to add to running total until chars are exhausted
+func romanToArabic(str string) (uint16, error) {
+	var val uint16
+
+	for i := 0; i < len(str); {
+		c := str[i]
+
+		// grab next char if possible
+		var xc []byte
+		if i+1 < len(str) {
+			xc = append([]byte{c}, str[i+1])
+		}
+
+		// check two char sequence first
+		if v, fnd := rToaMap[string(xc)]; fnd {
+			val += v
+			i += 2
+			continue
+		}
+
+		if v, fnd := rToaMap[string(c)]; fnd {
+			val += v
+			i++
+			continue
+		}
+
+		return 0, ErrInvalidNumeral
+	}
+
+	return val, nil
+}
+
+// arabicToRoman takes an arabic numeral and returns a roman numeral.
+//
+//	loop over map to find greatest match for current value
+//	append the value and decrease current by key
+//	continue until current is zero
+func arabicToRoman(val uint16, useMap map[uint16]string) string {
+	var (
+		outStr  string
+		current = val
+	)
+
+	for current > 0 {
+		a, r := findLargest(current, useMap)
+		outStr += r
+		current -= a
+	}
+
+	return outStr
+}
+
+// findLargest finds the largest key in m that is <= n.
+func findLargest(n uint16, m map[uint16]string) (a uint16, r string) {
+	var (
+		lAr uint16
+		lRm string
+	)
+	for k, v := range m {
+		if n >= k && k > lAr {
+			lAr = k
+			lRm = v
+		}
+	}
+
+	return lAr, lRm
+}
+
+// makeAddMap takes an existing arabic->roman map and returns a new map
+// sans subtractive elements.
+func makeAddMap(inmap map[uint16]string) map[uint16]string {
+	outmap := map[uint16]string{}
+	for k, v := range inmap {
+		if len(v) == 1 { // assume all subtractive keys are two bytes
+			outmap[k] = v
+		}
+	}
+	return outmap
+}
@@ -0,0 +1,157 @@
+package roman
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// maxCanonical is the largest value that round-trips through canonical
+// subtractive grammar (MMMCMXCIX = 3999); 4000 ("MMMM") is accepted by
+// IsValidArabic but is not canonical, so it is excluded from these
+// round-trip properties.
+const maxCanonical = 3999
+
+// arabicVal is a uint16 restricted to [1, maxCanonical] so that
+// testing/quick exercises only values the converter round-trips, and so
+// shrinking on a failure still produces a valid, minimal counterexample.
+type arabicVal uint16
+
+func (arabicVal) Generate(rnd *rand.Rand, size int) reflect.Value {
+	v := uint16(rnd.Intn(maxCanonical) + 1)
+	return reflect.ValueOf(arabicVal(v))
+}
+
+func TestQuickRoundTripSubtractive(t *testing.T) {
+	f := func(n arabicVal) bool {
+		r, err := ConvertToRoman(uint16(n))
+		if err != nil {
+			t.Logf("ConvertToRoman(%d): %v", n, err)
+			return false
+		}
+
+		back, err := ConvertToArabic(r)
+		if err != nil {
+			t.Logf("ConvertToArabic(%q): %v", r, err)
+			return false
+		}
+
+		return back == uint16(n)
+	}
+
+	if err := quick.Check(f, &quick.Config{MaxCount: 4000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickRoundTripAdditive(t *testing.T) {
+	f := func(n arabicVal) bool {
+		r, err := ConvertToRomanAdditive(uint16(n))
+		if err != nil {
+			t.Logf("ConvertToRomanAdditive(%d): %v", n, err)
+			return false
+		}
+
+		back, err := ConvertToArabicLax(r)
+		if err != nil {
+			t.Logf("ConvertToArabicLax(%q): %v", r, err)
+			return false
+		}
+
+		return back == uint16(n)
+	}
+
+	if err := quick.Check(f, &quick.Config{MaxCount: 4000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickRomanStringRoundTrip(t *testing.T) {
+	// for every valid canonical roman string, converting to arabic and
+	// back yields the same string
+	f := func(n arabicVal) bool {
+		r, err := ConvertToRoman(uint16(n))
+		if err != nil {
+			return false
+		}
+
+		val, err := ConvertToArabic(r)
+		if err != nil {
+			return false
+		}
+
+		back, err := ConvertToRoman(val)
+		if err != nil {
+			return false
+		}
+
+		return back == r
+	}
+
+	if err := quick.Check(f, &quick.Config{MaxCount: 4000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickShapeSubtractive(t *testing.T) {
+	f := func(n arabicVal) bool {
+		r, err := ConvertToRoman(uint16(n))
+		if err != nil {
+			return false
+		}
+
+		return len(r) <= 15 && onlyRomanChars(r)
+	}
+
+	if err := quick.Check(f, &quick.Config{MaxCount: 4000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickShapeAdditive(t *testing.T) {
+	f := func(n arabicVal) bool {
+		r, err := ConvertToRomanAdditive(uint16(n))
+		if err != nil {
+			return false
+		}
+
+		// additive forms repeat symbols instead of subtracting, so they
+		// run wider than the 15-char subtractive bound.
+		return len(r) <= 36 && onlyRomanChars(r)
+	}
+
+	if err := quick.Check(f, &quick.Config{MaxCount: 4000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func onlyRomanChars(s string) bool {
+	return strings.Trim(s, "IVXLCDM") == ""
+}
+
+// TestConvertToRomanAdditiveMaxValRoundTrip covers 4000 ("MMMM"), the one
+// value above maxCanonical that ConvertToRomanAdditive still accepts;
+// ConvertToRoman rejects it since it isn't canonical.
+func TestConvertToRomanAdditiveMaxValRoundTrip(t *testing.T) {
+	r, err := ConvertToRomanAdditive(4000)
+	if err != nil {
+		t.Fatalf("ConvertToRomanAdditive(4000): %v", err)
+	}
+	if r != "MMMM" {
+		t.Errorf("ConvertToRomanAdditive(4000) = %q, want %q", r, "MMMM")
+	}
+
+	back, err := ConvertToArabicLax(r)
+	if err != nil {
+		t.Fatalf("ConvertToArabicLax(%q): %v", r, err)
+	}
+	if back != 4000 {
+		t.Errorf("ConvertToArabicLax(%q) = %d, want 4000", r, back)
+	}
+
+	if _, err := ConvertToRoman(4000); err == nil {
+		t.Error("ConvertToRoman(4000) = nil error, want ErrOutOfRange (not canonical)")
+	}
+}